@@ -0,0 +1,123 @@
+package reddit
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	_ "golang.org/x/image/webp"
+)
+
+// ErrBelowMinResolution marks a processImage rejection as permanent: the
+// image itself falls below the configured minimum resolution, so retrying
+// the download on a later run would just reject it again.
+var ErrBelowMinResolution = errors.New("image is below the configured minimum resolution")
+
+// thumbnailMaxEdge is the longest edge, in pixels, of the companion thumbnail
+// written for every processed image.
+const thumbnailMaxEdge = 400
+
+// thumbnailQuality is the JPEG quality used when encoding a thumbnail.
+const thumbnailQuality = 80
+
+// thumbnailsDirName is the sibling directory thumbnails are written into,
+// kept alongside the originals rather than mixed in with them.
+const thumbnailsDirName = ".thumbnails"
+
+// isVideoLink reports whether the given link points at a mp4 (including gifvs,
+// which downloadImage already rewrites to mp4 before it ever reaches here),
+// which is exempt from image decoding but is still counted as processed.
+func isVideoLink(link string) bool {
+	return strings.HasSuffix(link, "mp4")
+}
+
+// processImage decodes a freshly downloaded image, deletes and rejects it if
+// it falls below the configured minimum resolution (useful for wallpaper subs
+// where thumbnails and icons sneak through), downscales it with a Lanczos
+// filter if it exceeds the configured maximum, and writes a companion
+// thumbnail alongside it. Videos are exempt from decoding entirely, but are
+// still reported as processed by the caller.
+func (s Scraper) processImage(imagePath string, img Image) error {
+	if isVideoLink(img.link) {
+		return nil
+	}
+
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return err
+	}
+
+	decoded, _, err := image.Decode(file)
+	_ = file.Close()
+
+	if err != nil {
+		return err
+	}
+
+	bounds := decoded.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	belowMin := (s.scrapingOptions.MinWidth > 0 && width < s.scrapingOptions.MinWidth) ||
+		(s.scrapingOptions.MinHeight > 0 && height < s.scrapingOptions.MinHeight)
+
+	if belowMin {
+		_ = os.Remove(imagePath)
+		return fmt.Errorf("%w: %vx%v", ErrBelowMinResolution, width, height)
+	}
+
+	aboveMax := (s.scrapingOptions.MaxWidth > 0 && width > s.scrapingOptions.MaxWidth) ||
+		(s.scrapingOptions.MaxHeight > 0 && height > s.scrapingOptions.MaxHeight)
+
+	if aboveMax {
+		fitWidth, fitHeight := resizeBounds(s.scrapingOptions.MaxWidth, s.scrapingOptions.MaxHeight, width, height)
+		decoded = imaging.Fit(decoded, fitWidth, fitHeight, imaging.Lanczos)
+
+		if err := imaging.Save(decoded, imagePath); err != nil {
+			return err
+		}
+	}
+
+	return s.writeThumbnail(imagePath, decoded)
+}
+
+// resizeBounds returns the width/height to pass to imaging.Fit. An axis that
+// isn't configured (left at its zero value) is clamped to the source image's
+// own dimension, since imaging.Fit treats a <=0 bound as "fit into nothing"
+// and returns a blank 0x0 image rather than leaving that axis unconstrained.
+func resizeBounds(maxWidth, maxHeight, width, height int) (int, int) {
+	if maxWidth <= 0 {
+		maxWidth = width
+	}
+
+	if maxHeight <= 0 {
+		maxHeight = height
+	}
+
+	return maxWidth, maxHeight
+}
+
+// writeThumbnail writes a thumbnailMaxEdge-longest-edge, JPEG-quality
+// thumbnailQuality copy of decoded into a sibling thumbnailsDirName directory
+// next to imagePath.
+func (s Scraper) writeThumbnail(imagePath string, decoded image.Image) error {
+	dir := filepath.Join(filepath.Dir(imagePath), thumbnailsDirName)
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	thumbnail := imaging.Fit(decoded, thumbnailMaxEdge, thumbnailMaxEdge, imaging.Lanczos)
+
+	base := filepath.Base(imagePath)
+	thumbnailName := strings.TrimSuffix(base, filepath.Ext(base)) + ".jpg"
+	thumbnailPath := filepath.Join(dir, thumbnailName)
+
+	return imaging.Save(thumbnail, thumbnailPath, imaging.JPEGQuality(thumbnailQuality))
+}
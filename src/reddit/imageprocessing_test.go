@@ -0,0 +1,15 @@
+package reddit
+
+import "testing"
+
+func TestResizeBoundsClampsUnsetAxis(t *testing.T) {
+	width, height := resizeBounds(0, 600, 4000, 3000)
+
+	if width != 4000 {
+		t.Errorf("expected width to clamp to the source width 4000 when MaxWidth is unset, got %v", width)
+	}
+
+	if height != 600 {
+		t.Errorf("expected height to stay at the configured MaxHeight 600, got %v", height)
+	}
+}
@@ -0,0 +1,172 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// imgurAlbumURLPattern matches an imgur album or gallery link and captures the
+// hash that identifies it, e.g. https://imgur.com/a/AbCdEfg or
+// https://imgur.com/gallery/AbCdEfg.
+var imgurAlbumURLPattern = regexp.MustCompile(`^https?://(?:www\.)?imgur\.com/(?:a|gallery)/([a-zA-Z0-9]+)`)
+
+// imgurSingleURLPattern matches a bare imgur page link, which reddit sometimes
+// posts instead of hot-linking the image directly, e.g. https://imgur.com/AbCdEfg.
+var imgurSingleURLPattern = regexp.MustCompile(`^https?://(?:www\.)?imgur\.com/([a-zA-Z0-9]+)$`)
+
+// ImageResolver expands a single post link into zero or more direct, downloadable
+// image urls. This exists so that hosts which hide more than one image behind a
+// single post link (imgur albums/galleries today, potentially redgifs or
+// i.redd.it galleries later) can plug into the parsing pipeline without
+// downloadMetadata having to know about each host's quirks.
+type ImageResolver interface {
+	// Matches returns true if this resolver knows how to expand the given link.
+	Matches(link string) bool
+	// Resolve takes the original link and returns the direct image urls it expands to.
+	Resolve(link string) ([]string, error)
+}
+
+// imgurAjaxAlbumResponse is the shape of the `ajaxalbums/getimages` response used
+// for both albums and galleries, a list of every image the album contains.
+type imgurAjaxAlbumResponse struct {
+	Data struct {
+		Images []struct {
+			Hash string `json:"hash"`
+			Ext  string `json:"ext"`
+		} `json:"images"`
+	} `json:"data"`
+}
+
+// imgurAjaxImageResponse is the shape of the `ajax/image` response used for a
+// bare single-image imgur page, which isn't an album and so isn't listed in
+// the ajaxalbums response.
+type imgurAjaxImageResponse struct {
+	Data struct {
+		Image struct {
+			Hash string `json:"hash"`
+			Ext  string `json:"ext"`
+		} `json:"image"`
+	} `json:"data"`
+}
+
+// imgurResolver resolves imgur albums, galleries and bare single-image pages
+// down to their direct i.imgur.com urls using imgur's own ajax endpoints, the
+// same ones the imgur.com frontend itself calls.
+type imgurResolver struct {
+	httpClient *http.Client
+}
+
+// newImgurResolver creates a imgur resolver ready to be registered against a Scraper.
+func newImgurResolver() *imgurResolver {
+	return &imgurResolver{httpClient: &http.Client{}}
+}
+
+func (r *imgurResolver) Matches(link string) bool {
+	return imgurAlbumURLPattern.MatchString(link) || imgurSingleURLPattern.MatchString(link)
+}
+
+// Resolve fetches the album/gallery/single-image hash behind the link and
+// returns every direct image url it contains. A bare single-image page hits
+// imgur's single-image endpoint rather than the album-listing one, since the
+// latter has nothing to list for a hash that isn't an album.
+func (r *imgurResolver) Resolve(link string) ([]string, error) {
+	hash := imgurHashFromURL(link)
+	if hash == "" {
+		return nil, fmt.Errorf("could not determine imgur hash from %q", link)
+	}
+
+	if imgurSingleURLPattern.MatchString(link) {
+		return r.resolveSingle(hash)
+	}
+
+	return r.resolveAlbum(hash)
+}
+
+// resolveAlbum fetches every image an imgur album/gallery hash contains.
+func (r *imgurResolver) resolveAlbum(hash string) ([]string, error) {
+	endpoint := fmt.Sprintf("https://imgur.com/ajaxalbums/getimages/%s/hit.json?all=true", hash)
+	body, err := r.get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var album imgurAjaxAlbumResponse
+	if err := json.Unmarshal(body, &album); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(album.Data.Images))
+	for _, image := range album.Data.Images {
+		urls = append(urls, fmt.Sprintf("https://i.imgur.com/%s%s", image.Hash, image.Ext))
+	}
+
+	return urls, nil
+}
+
+// resolveSingle fetches the direct image behind a bare single-image imgur
+// page hash.
+func (r *imgurResolver) resolveSingle(hash string) ([]string, error) {
+	endpoint := fmt.Sprintf("https://imgur.com/ajax/image/%s", hash)
+	body, err := r.get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var single imgurAjaxImageResponse
+	if err := json.Unmarshal(body, &single); err != nil {
+		return nil, err
+	}
+
+	if single.Data.Image.Hash == "" {
+		return nil, fmt.Errorf("imgur single-image endpoint returned no image for hash %q", hash)
+	}
+
+	return []string{fmt.Sprintf("https://i.imgur.com/%s%s", single.Data.Image.Hash, single.Data.Image.Ext)}, nil
+}
+
+// get issues a browser-user-agented GET against an imgur ajax endpoint and
+// returns the raw response body.
+func (r *imgurResolver) get(endpoint string) ([]byte, error) {
+	req, _ := http.NewRequest("GET", endpoint, nil)
+	req.Header.Set("user-agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(resp.Body)
+	return ioutil.ReadAll(resp.Body)
+}
+
+// imgurHashFromURL pulls the album/gallery/single-page hash out of an imgur link,
+// or returns an empty string if the link doesn't match any known imgur pattern.
+func imgurHashFromURL(link string) string {
+	if match := imgurAlbumURLPattern.FindStringSubmatch(link); match != nil {
+		return match[1]
+	}
+
+	if match := imgurSingleURLPattern.FindStringSubmatch(link); match != nil {
+		return match[1]
+	}
+
+	return ""
+}
+
+// isResolvableImgurLink returns true for imgur links that aren't already a direct
+// image (an album, gallery, or bare single-image page), the kind that
+// parseLinksFromListings would otherwise drop for lacking a file extension.
+func isResolvableImgurLink(link string) bool {
+	return imgurAlbumURLPattern.MatchString(link) || imgurSingleURLPattern.MatchString(link)
+}
+
+// directImageHash returns the filename (sans extension) of a direct image url,
+// used to derive the child half of a resolved album image's id.
+func directImageHash(link string) string {
+	split := strings.Split(link, "/")
+	return strings.Split(split[len(split)-1], ".")[0]
+}
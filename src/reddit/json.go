@@ -23,6 +23,9 @@ type Listings struct {
 type ListingData struct {
 	Dist     *int64  `json:"dist,omitempty"`
 	Children []Child `json:"children"`
+	// After is reddit's pagination cursor for this listing, used to fetch the
+	// next page of results. Nil (or empty) means there is nothing more to page.
+	After *string `json:"after,omitempty"`
 }
 
 type Child struct {
@@ -0,0 +1,170 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redditAccessTokenURL is reddit's OAuth2 token endpoint, used for the
+// password (script app) grant.
+const redditAccessTokenURL = "https://www.reddit.com/api/v1/access_token"
+
+// redditOAuthBaseURL is where every authenticated request is routed to,
+// instead of the anonymous www.reddit.com host.
+const redditOAuthBaseURL = "https://oauth.reddit.com"
+
+// oauthClient is a authenticated reddit client using the password (script
+// app) grant, transparently refreshing its access token and backing off when
+// reddit's rate limit headers say we're close to exhausting it.
+type oauthClient struct {
+	clientID     string
+	clientSecret string
+	username     string
+	password     string
+	userAgent    string
+
+	httpClient *http.Client
+
+	mutex       sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// newOAuthClient creates a client ready to authenticate, or returns ok=false
+// if options doesn't configure reddit credentials, in which case the caller
+// should fall back to the anonymous endpoint.
+func newOAuthClient(options Options) (client *oauthClient, ok bool) {
+	if options.RedditClientID == "" || options.RedditClientSecret == "" ||
+		options.RedditUsername == "" || options.RedditPassword == "" {
+		return nil, false
+	}
+
+	userAgent := options.RedditUserAgent
+	if strings.TrimSpace(userAgent) == "" {
+		userAgent = "Mavic/1.0"
+	}
+
+	return &oauthClient{
+		clientID:     options.RedditClientID,
+		clientSecret: options.RedditClientSecret,
+		username:     options.RedditUsername,
+		password:     options.RedditPassword,
+		userAgent:    userAgent,
+		httpClient:   &http.Client{},
+	}, true
+}
+
+// token returns a valid access token, re-authenticating if one has never
+// been fetched or is about to expire.
+func (c *oauthClient) token() (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.accessToken != "" && time.Now().Add(time.Minute).Before(c.expiresAt) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("username", c.username)
+	form.Set("password", c.password)
+
+	req, err := http.NewRequest("POST", redditAccessTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.SetBasicAuth(c.clientID, c.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("user-agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+
+	defer Close(resp.Body)
+	body, _ := ioutil.ReadAll(resp.Body)
+
+	var tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return "", err
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return "", fmt.Errorf("reddit did not return an access token: %s", body)
+	}
+
+	c.accessToken = tokenResponse.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+
+	return c.accessToken, nil
+}
+
+// get performs a authenticated GET against oauth.reddit.com, honoring
+// X-Ratelimit-Remaining/X-Ratelimit-Reset by sleeping when we're close to
+// exhausting our window, rather than letting reddit start returning 429s
+// partway through a long paging run.
+func (c *oauthClient) get(requestPath string) ([]byte, error) {
+	token, err := c.token()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", redditOAuthBaseURL+requestPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("user-agent", c.userAgent)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(resp.Body)
+	c.throttle(resp.Header)
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+// throttle sleeps until reddit's rate limit window resets if we're down to
+// our last couple of requests, so a long paging run doesn't start getting
+// rate limited partway through.
+func (c *oauthClient) throttle(header http.Header) {
+	if delay, ok := throttleDelay(header); ok {
+		time.Sleep(delay)
+	}
+}
+
+// throttleDelay decides how long to sleep before the next request based on
+// reddit's rate limit headers, without performing the sleep itself, so the
+// decision can be unit tested without a real clock. ok is false when we're
+// not close enough to the limit to bother waiting, or the headers are
+// missing/malformed.
+func throttleDelay(header http.Header) (delay time.Duration, ok bool) {
+	remaining, err := strconv.ParseFloat(header.Get("X-Ratelimit-Remaining"), 64)
+	if err != nil || remaining > 2 {
+		return 0, false
+	}
+
+	resetSeconds, err := strconv.ParseFloat(header.Get("X-Ratelimit-Reset"), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(resetSeconds) * time.Second, true
+}
@@ -0,0 +1,60 @@
+package reddit
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestThrottleDelayWaitsWhenRunningLow(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Ratelimit-Remaining", "1")
+	header.Set("X-Ratelimit-Reset", "30")
+
+	delay, ok := throttleDelay(header)
+	if !ok {
+		t.Fatal("expected to throttle when only 1 request remains")
+	}
+
+	if delay != 30*time.Second {
+		t.Errorf("got delay %v, want %v", delay, 30*time.Second)
+	}
+}
+
+func TestThrottleDelayPlentyRemaining(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Ratelimit-Remaining", "50")
+	header.Set("X-Ratelimit-Reset", "30")
+
+	if _, ok := throttleDelay(header); ok {
+		t.Error("expected no throttle with 50 requests remaining")
+	}
+}
+
+func TestThrottleDelayMissingHeaders(t *testing.T) {
+	if _, ok := throttleDelay(http.Header{}); ok {
+		t.Error("expected no throttle when rate limit headers are absent")
+	}
+}
+
+func TestNewOAuthClientRequiresAllCredentials(t *testing.T) {
+	_, ok := newOAuthClient(Options{RedditClientID: "id"})
+	if ok {
+		t.Error("expected newOAuthClient to report ok=false with only a client id configured")
+	}
+
+	client, ok := newOAuthClient(Options{
+		RedditClientID:     "id",
+		RedditClientSecret: "secret",
+		RedditUsername:     "user",
+		RedditPassword:     "pass",
+	})
+
+	if !ok {
+		t.Fatal("expected newOAuthClient to report ok=true with every credential configured")
+	}
+
+	if client.userAgent != "Mavic/1.0" {
+		t.Errorf("got default userAgent %q, want %q", client.userAgent, "Mavic/1.0")
+	}
+}
@@ -0,0 +1,101 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// ChannelBus is the default, in-process Bus implementation, backed by one
+// buffered Go channel per topic. Messages are not durable: a crash or
+// restart loses anything still in flight, which is fine for a casual,
+// one-shot run of Mavic.
+type ChannelBus struct {
+	mutex  sync.Mutex
+	topics map[string]chan Message
+}
+
+// NewChannelBus creates a empty in-process bus.
+func NewChannelBus() *ChannelBus {
+	return &ChannelBus{topics: map[string]chan Message{}}
+}
+
+// topic returns the channel backing a topic, creating it on first use.
+func (b *ChannelBus) topic(name string) chan Message {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, ok := b.topics[name]; !ok {
+		b.topics[name] = make(chan Message, 64)
+	}
+
+	return b.topics[name]
+}
+
+func (b *ChannelBus) Publish(topic string, msg Message) error {
+	b.topic(topic) <- msg
+	return nil
+}
+
+// Subscribe returns a channel that forwards everything published to topic
+// until either the topic is closed or ctx is cancelled, at which point it is
+// closed in turn. This is what lets a daemon shutdown stop a subscriber like
+// downloadImages that would otherwise range over the topic forever.
+func (b *ChannelBus) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	src := b.topic(topic)
+	out := make(chan Message)
+
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-src:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Ack is a no-op for the in-process bus, since there is nothing further to
+// acknowledge once a message has already been received off the channel.
+func (b *ChannelBus) Ack(msg Message) error {
+	return nil
+}
+
+// Nack re-publishes the message so it gets picked up again, since the
+// in-process bus has no separate redelivery mechanism to lean on.
+func (b *ChannelBus) Nack(msg Message) error {
+	return b.Publish(msg.Topic, msg)
+}
+
+// CloseTopic closes the given topic's channel, letting any subscriber still
+// ranging over it finish cleanly.
+func (b *ChannelBus) CloseTopic(topic string) error {
+	close(b.topic(topic))
+	return nil
+}
+
+// Close closes every topic channel, signalling all subscribers to stop.
+func (b *ChannelBus) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for name, ch := range b.topics {
+		close(ch)
+		delete(b.topics, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestChannelBusPublishSubscribe(t *testing.T) {
+	bus := NewChannelBus()
+
+	msgs, err := bus.Subscribe(context.Background(), "topic")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := bus.Publish("topic", Message{ID: "1", Topic: "topic", Payload: []byte("hello")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	select {
+	case msg := <-msgs:
+		if string(msg.Payload) != "hello" {
+			t.Errorf("got payload %q, want %q", msg.Payload, "hello")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the published message")
+	}
+}
+
+func TestChannelBusSubscribeStopsOnContextCancel(t *testing.T) {
+	bus := NewChannelBus()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	msgs, err := bus.Subscribe(ctx, "topic")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-msgs:
+		if ok {
+			t.Error("expected the subscriber channel to be closed after ctx is cancelled")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the subscriber channel to close after cancellation")
+	}
+}
+
+func TestChannelBusNackRedelivers(t *testing.T) {
+	bus := NewChannelBus()
+
+	msgs, err := bus.Subscribe(context.Background(), "topic")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := bus.Publish("topic", Message{ID: "1", Topic: "topic", Payload: []byte("first")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	first := <-msgs
+	if err := bus.Nack(first); err != nil {
+		t.Fatalf("Nack() error = %v", err)
+	}
+
+	select {
+	case redelivered := <-msgs:
+		if string(redelivered.Payload) != "first" {
+			t.Errorf("got redelivered payload %q, want %q", redelivered.Payload, "first")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for Nack to redeliver the message")
+	}
+}
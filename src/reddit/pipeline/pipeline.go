@@ -0,0 +1,89 @@
+// Package pipeline provides a small message-bus abstraction that lets metadata
+// fetching and image downloading run as two independently-tunable worker
+// pools, connected through a Publisher/Subscriber instead of sharing a single
+// channel and semaphore the way the scraper used to.
+package pipeline
+
+import (
+	"context"
+	"time"
+)
+
+// Topics used across the scraper's pipeline.
+const (
+	// TopicImageDownload carries every image that has been resolved from a
+	// listing and is ready to be downloaded.
+	TopicImageDownload = "image.download"
+	// TopicDownloadStatus carries a status update for every download attempt,
+	// which is what drives the progress bar (and, later, other frontends).
+	TopicDownloadStatus = "download.status"
+)
+
+// Message is a single unit of work (or status update) flowing through the
+// pipeline. Payload is left as raw bytes so Bus implementations don't need to
+// know anything about the reddit package's types.
+type Message struct {
+	// ID identifies the message so a durable Bus can dedupe redeliveries.
+	ID string
+	// Topic is the topic the message was published to.
+	Topic string
+	// Payload is the json-encoded body of the message.
+	Payload []byte
+	// Attempts counts how many times SQLBus has delivered this message,
+	// incremented on every poll that hands it out. ChannelBus does not track
+	// it and leaves it at zero.
+	Attempts int
+	// Deadline is when a unacked message becomes eligible for redelivery, so
+	// a crashed or timed-out worker doesn't lose the message for good.
+	Deadline time.Time
+}
+
+// Publisher sends messages onto a topic.
+type Publisher interface {
+	Publish(topic string, msg Message) error
+}
+
+// Subscriber receives messages published to a topic. Ack must be called once a
+// message has been fully processed; Nack (or simply letting the ack deadline
+// pass) makes the message eligible for redelivery.
+type Subscriber interface {
+	Subscribe(ctx context.Context, topic string) (<-chan Message, error)
+	Ack(msg Message) error
+	Nack(msg Message) error
+}
+
+// Bus is the combination most callers want: something that can both publish
+// and subscribe, plus the lifecycle calls needed to wind a topic/the whole
+// bus down cleanly.
+type Bus interface {
+	Publisher
+	Subscriber
+	// CloseTopic signals that no more messages will be published to topic, so
+	// a subscriber still ranging over it can finish cleanly. A durable bus
+	// may treat this as a no-op (SQLBus does), in which case a subscriber to
+	// the topic keeps polling and only stops once its ctx is cancelled.
+	CloseTopic(topic string) error
+	Close() error
+}
+
+// Pipeline separates metadata fetching and image downloading into two
+// independently-tunable worker pools, connected through a Bus.
+type Pipeline struct {
+	Bus Bus
+	// MaxConcurrentSubreddits bounds how many subreddit listing/metadata
+	// fetches run at once.
+	MaxConcurrentSubreddits int
+	// MaxConcurrentDownloads bounds how many image downloads run at once,
+	// independent of how many metadata fetches are in flight.
+	MaxConcurrentDownloads int
+}
+
+// NewPipeline creates a Pipeline backed by the default, in-process ChannelBus.
+// Call WithBus on the owning Scraper to opt into a durable bus instead.
+func NewPipeline(maxConcurrentSubreddits, maxConcurrentDownloads int) *Pipeline {
+	return &Pipeline{
+		Bus:                     NewChannelBus(),
+		MaxConcurrentSubreddits: maxConcurrentSubreddits,
+		MaxConcurrentDownloads:  maxConcurrentDownloads,
+	}
+}
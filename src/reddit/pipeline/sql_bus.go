@@ -0,0 +1,159 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqlPollInterval is how often a SQLBus subscriber checks for newly eligible
+// messages, balancing responsiveness against hammering the database.
+const sqlPollInterval = time.Second
+
+// SQLBus is a opt-in, durable Bus backed by a sqlite table, so a message still
+// "in flight" (published but not yet acked) when Mavic is killed or crashes
+// gets picked back up and retried on the next run instead of being silently
+// lost the way the in-process ChannelBus would lose it.
+type SQLBus struct {
+	db *sql.DB
+}
+
+// NewSQLBus opens (creating if needed) a sqlite-backed bus at path.
+func NewSQLBus(path string) (*SQLBus, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	bus := &SQLBus{db: db}
+	if err := bus.migrate(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return bus, nil
+}
+
+func (b *SQLBus) migrate() error {
+	_, err := b.db.Exec(`
+CREATE TABLE IF NOT EXISTS messages (
+	id       TEXT NOT NULL,
+	topic    TEXT NOT NULL,
+	payload  BLOB NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	deadline TIMESTAMP,
+	acked    BOOLEAN NOT NULL DEFAULT 0,
+	PRIMARY KEY (id, topic)
+);
+`)
+
+	return err
+}
+
+func (b *SQLBus) Publish(topic string, msg Message) error {
+	var deadline interface{}
+	if !msg.Deadline.IsZero() {
+		deadline = msg.Deadline.UTC()
+	}
+
+	_, err := b.db.Exec(`
+INSERT OR REPLACE INTO messages (id, topic, payload, attempts, deadline, acked)
+VALUES (?, ?, ?, ?, ?, 0)
+`, msg.ID, topic, msg.Payload, msg.Attempts, deadline)
+
+	return err
+}
+
+// Subscribe polls the table for unacked messages that have never been
+// delivered or are past their deadline, so a message orphaned by a crashed or
+// timed-out worker is picked back up once its deadline passes instead of
+// being lost for good.
+func (b *SQLBus) Subscribe(ctx context.Context, topic string) (<-chan Message, error) {
+	out := make(chan Message)
+
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(sqlPollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				b.poll(ctx, topic, out)
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// poll fetches every message on topic that is unacked and either not yet
+// delivered or past its redelivery deadline, marks it delivered with a fresh
+// deadline, and hands it to out.
+func (b *SQLBus) poll(ctx context.Context, topic string, out chan<- Message) {
+	rows, err := b.db.QueryContext(ctx, `
+SELECT id, payload, attempts, deadline FROM messages
+WHERE topic = ? AND acked = 0 AND (attempts = 0 OR deadline IS NULL OR deadline <= ?)
+`, topic, time.Now().UTC())
+
+	if err != nil {
+		return
+	}
+
+	var pending []Message
+
+	for rows.Next() {
+		var msg Message
+		var deadline sql.NullTime
+
+		if err := rows.Scan(&msg.ID, &msg.Payload, &msg.Attempts, &deadline); err != nil {
+			continue
+		}
+
+		msg.Topic = topic
+		if deadline.Valid {
+			msg.Deadline = deadline.Time
+		}
+
+		pending = append(pending, msg)
+	}
+
+	_ = rows.Close()
+
+	for _, msg := range pending {
+		msg.Attempts++
+		msg.Deadline = time.Now().Add(5 * time.Minute)
+
+		_, _ = b.db.ExecContext(ctx, `UPDATE messages SET attempts = ?, deadline = ? WHERE id = ? AND topic = ?`,
+			msg.Attempts, msg.Deadline.UTC(), msg.ID, topic)
+
+		out <- msg
+	}
+}
+
+func (b *SQLBus) Ack(msg Message) error {
+	_, err := b.db.Exec(`UPDATE messages SET acked = 1 WHERE id = ? AND topic = ?`, msg.ID, msg.Topic)
+	return err
+}
+
+// Nack clears the message's deadline so it becomes immediately eligible for
+// redelivery on the next poll.
+func (b *SQLBus) Nack(msg Message) error {
+	_, err := b.db.Exec(`UPDATE messages SET deadline = NULL WHERE id = ? AND topic = ?`, msg.ID, msg.Topic)
+	return err
+}
+
+// CloseTopic is a no-op: a durable bus has no notion of "no more messages
+// coming", subscribers just keep polling for whatever the next run publishes.
+func (b *SQLBus) CloseTopic(topic string) error {
+	return nil
+}
+
+func (b *SQLBus) Close() error {
+	return b.db.Close()
+}
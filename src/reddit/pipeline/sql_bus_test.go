@@ -0,0 +1,83 @@
+package pipeline
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSQLBusPublishSubscribeAck(t *testing.T) {
+	bus, err := NewSQLBus(filepath.Join(t.TempDir(), "bus.db"))
+	if err != nil {
+		t.Fatalf("NewSQLBus() error = %v", err)
+	}
+	defer bus.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msgs, err := bus.Subscribe(ctx, "topic")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := bus.Publish("topic", Message{ID: "1", Topic: "topic", Payload: []byte("hello")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	var msg Message
+	select {
+	case msg = <-msgs:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the published message to be polled")
+	}
+
+	if string(msg.Payload) != "hello" {
+		t.Errorf("got payload %q, want %q", msg.Payload, "hello")
+	}
+
+	if err := bus.Ack(msg); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+
+	select {
+	case redelivered := <-msgs:
+		t.Fatalf("expected an acked message to not be redelivered, got %+v", redelivered)
+	case <-time.After(2 * sqlPollInterval):
+	}
+}
+
+func TestSQLBusNackRedelivers(t *testing.T) {
+	bus, err := NewSQLBus(filepath.Join(t.TempDir(), "bus.db"))
+	if err != nil {
+		t.Fatalf("NewSQLBus() error = %v", err)
+	}
+	defer bus.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+
+	msgs, err := bus.Subscribe(ctx, "topic")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if err := bus.Publish("topic", Message{ID: "1", Topic: "topic", Payload: []byte("hello")}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	first := <-msgs
+	if err := bus.Nack(first); err != nil {
+		t.Fatalf("Nack() error = %v", err)
+	}
+
+	select {
+	case redelivered := <-msgs:
+		if redelivered.Attempts <= first.Attempts {
+			t.Errorf("expected Attempts to increase on redelivery, got %v then %v", first.Attempts, redelivered.Attempts)
+		}
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for Nack to redeliver the message")
+	}
+}
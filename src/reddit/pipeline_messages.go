@@ -0,0 +1,110 @@
+package reddit
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MambaSoftware/Mavic/src/reddit/pipeline"
+)
+
+// downloadAckDeadline bounds how long a published image/status message is
+// allowed to stay unacked before it is considered abandoned (its worker
+// crashed or hung) and becomes eligible for redelivery.
+const downloadAckDeadline = 5 * time.Minute
+
+// imageMessage is the json-serializable form of Image used when publishing
+// onto the image.download topic, since Bus implementations know nothing
+// about reddit's internal, unexported Image/Author types.
+type imageMessage struct {
+	AuthorName string `json:"authorName"`
+	AuthorLink string `json:"authorLink"`
+	ID         string `json:"id"`
+	ImageID    string `json:"imageId"`
+	PostLink   string `json:"postLink"`
+	Link       string `json:"link"`
+	Title      string `json:"title"`
+	Subreddit  string `json:"subreddit"`
+	Source     string `json:"source"`
+}
+
+// newImageMessage flattens an Image down to its json-serializable form.
+func newImageMessage(img Image) imageMessage {
+	return imageMessage{
+		AuthorName: img.author.name,
+		AuthorLink: img.author.link,
+		ID:         img.id,
+		ImageID:    img.imageId,
+		PostLink:   img.postLink,
+		Link:       img.link,
+		Title:      img.title,
+		Subreddit:  img.subreddit,
+		Source:     img.source,
+	}
+}
+
+// toImage rebuilds the Image an imageMessage was derived from.
+func (m imageMessage) toImage() Image {
+	return Image{
+		author:    Author{name: m.AuthorName, link: m.AuthorLink},
+		id:        m.ID,
+		imageId:   m.ImageID,
+		postLink:  m.PostLink,
+		link:      m.Link,
+		title:     m.Title,
+		subreddit: m.Subreddit,
+		source:    m.Source,
+	}
+}
+
+// statusMessage is the json-serializable form of a single download state
+// transition (the image and its new DownloadState) used when publishing onto
+// the download.status topic.
+type statusMessage struct {
+	Image imageMessage  `json:"image"`
+	State DownloadState `json:"state"`
+}
+
+// publishImage enqueues an image for download via the pipeline's image.download
+// topic, tagged with an ack deadline so a crashed/timed-out download is
+// retried rather than lost.
+func (s Scraper) publishImage(img Image) {
+	payload, err := json.Marshal(newImageMessage(img))
+	if err != nil {
+		fmt.Printf("Failed to encode image %v for download: %v\n", img.imageId, err)
+		return
+	}
+
+	msg := pipeline.Message{
+		ID:       img.subreddit + "/" + img.imageId,
+		Topic:    pipeline.TopicImageDownload,
+		Payload:  payload,
+		Deadline: time.Now().Add(downloadAckDeadline),
+	}
+
+	if err := s.pipeline.Bus.Publish(pipeline.TopicImageDownload, msg); err != nil {
+		fmt.Printf("Failed to publish image %v for download: %v\n", img.imageId, err)
+	}
+}
+
+// publishStatus reports a download state transition onto the pipeline's
+// download.status topic, which is what drives the progress bar (and, in the
+// future, any other frontend that wants to attach to the same topic).
+func (s Scraper) publishStatus(img Image, state DownloadState) {
+	payload, err := json.Marshal(statusMessage{Image: newImageMessage(img), State: state})
+	if err != nil {
+		fmt.Printf("Failed to encode status update for %v: %v\n", img.imageId, err)
+		return
+	}
+
+	msg := pipeline.Message{
+		ID:       img.subreddit + "/" + img.imageId + "/" + time.Now().String(),
+		Topic:    pipeline.TopicDownloadStatus,
+		Payload:  payload,
+		Deadline: time.Now().Add(downloadAckDeadline),
+	}
+
+	if err := s.pipeline.Bus.Publish(pipeline.TopicDownloadStatus, msg); err != nil {
+		fmt.Printf("Failed to publish status update for %v: %v\n", img.imageId, err)
+	}
+}
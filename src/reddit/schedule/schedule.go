@@ -0,0 +1,79 @@
+// Package schedule reads per-subreddit cron schedules from a config file and
+// ticks them against the cron library, leaving the caller to decide what a
+// tick actually does. Kept independent of the reddit package so it has no
+// opinion on what "mirroring a subreddit" means.
+package schedule
+
+import (
+	"os"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// Entry is a single subreddit's schedule: how often, as a standard 5-field
+// cron expression, new posts from it should be mirrored.
+type Entry struct {
+	Subreddit string `yaml:"subreddit"`
+	Cron      string `yaml:"cron"`
+}
+
+// Config is the parsed form of the scheduler's config file.
+type Config struct {
+	Subreddits []Entry `yaml:"subreddits"`
+}
+
+// Load reads and parses the YAML schedule config file at path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// Scheduler runs a callback on each subreddit's own cron schedule, and keeps
+// doing so until Stop is called.
+type Scheduler struct {
+	cron *cron.Cron
+}
+
+// New creates a Scheduler, registering tick against every entry in config.
+// Every entry is wrapped with SkipIfStillRunning, so a subreddit whose fetch
+// outlives its own cron interval just gets the overlapping tick skipped
+// instead of running concurrently with itself, and with Recover as a
+// backstop so a panic inside tick logs instead of taking the daemon down.
+func New(config Config, tick func(subreddit string)) (*Scheduler, error) {
+	c := cron.New(cron.WithChain(
+		cron.SkipIfStillRunning(cron.DefaultLogger),
+		cron.Recover(cron.DefaultLogger),
+	))
+
+	for _, entry := range config.Subreddits {
+		sub := entry.Subreddit
+
+		if _, err := c.AddFunc(entry.Cron, func() { tick(sub) }); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Scheduler{cron: c}, nil
+}
+
+// Start boots every registered cron job. Non-blocking: jobs run on their own
+// goroutines as the cron library ticks them.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop signals every running job to finish and blocks until they have,
+// giving an in-flight tick time to complete before the process exits.
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+}
@@ -0,0 +1,91 @@
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/MambaSoftware/Mavic/src/reddit/pipeline"
+	"github.com/MambaSoftware/Mavic/src/reddit/schedule"
+)
+
+// RunScheduled turns Mavic into a long-running daemon instead of a one-shot
+// run: it reads a schedule config (ScheduleConfigPath) mapping each subreddit
+// to its own cron expression, and on every tick downloads just that
+// subreddit's metadata. Combined with a persistent store, new posts get
+// mirrored continuously without ever re-downloading what's already been
+// seen. RunScheduled blocks until ctx is cancelled or a SIGINT/SIGTERM is
+// received, waiting for any in-flight downloads to finish before returning.
+func (s Scraper) RunScheduled(ctx context.Context) error {
+	config, err := schedule.Load(s.scrapingOptions.ScheduleConfigPath)
+	if err != nil {
+		return err
+	}
+
+	signalCtx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	var downloadWaitGroup sync.WaitGroup
+	downloadWaitGroup.Add(1)
+
+	go func() {
+		defer downloadWaitGroup.Done()
+		s.downloadImages(signalCtx)
+	}()
+
+	go s.logDownloadStatus(signalCtx)
+
+	var tickWaitGroup sync.WaitGroup
+
+	scheduler, err := schedule.New(config, func(sub string) {
+		tickWaitGroup.Add(1)
+		defer tickWaitGroup.Done()
+
+		var group sync.WaitGroup
+		group.Add(1)
+		s.downloadMetadata(sub, &group)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	scheduler.Start()
+
+	<-signalCtx.Done()
+
+	scheduler.Stop()
+	tickWaitGroup.Wait()
+
+	// downloadImages is subscribed against signalCtx too, so cancelling it
+	// above already told the consumer to stop; wait for whatever download it
+	// had in flight to actually finish before we return.
+	downloadWaitGroup.Wait()
+
+	return nil
+}
+
+// logDownloadStatus drains the pipeline's download.status topic for as long as
+// RunScheduled is active, logging each transition instead of driving the
+// progress bar a one-shot run uses, since a daemon has no one watching it.
+func (s Scraper) logDownloadStatus(ctx context.Context) {
+	statusChannel, err := s.pipeline.Bus.Subscribe(ctx, pipeline.TopicDownloadStatus)
+	if err != nil {
+		log.Printf("Failed to subscribe to download status updates: %v\n", err)
+		return
+	}
+
+	for msg := range statusChannel {
+		var status statusMessage
+
+		if err := json.Unmarshal(msg.Payload, &status); err == nil {
+			image := status.Image.toImage()
+			log.Printf("[r/%v] %v state=%v\n", image.subreddit, image.imageId, status.State)
+		}
+
+		_ = s.pipeline.Bus.Ack(msg)
+	}
+}
@@ -2,6 +2,9 @@ package reddit
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +17,8 @@ import (
 	"sync"
 
 	"github.com/IsaccBarker/progressbar"
+	"github.com/MambaSoftware/Mavic/src/reddit/pipeline"
+	"github.com/MambaSoftware/Mavic/src/reddit/store"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -30,19 +35,9 @@ const (
 	SUCCESS                   = 1
 	SKIPPED                   = 2
 	FAILED                    = 3
+	PROCESSED                 = 4
 )
 
-// updateState is used to determine how a downloading progress has occurred and on
-// what subreddit that this happened.
-type updateState struct {
-	// The image metadata that was used to download the given image, this will
-	// be used to correctly format a message that will be displayed briefly
-	// during the downloading process.
-	image Image
-	// The state that the downloading is currently in.
-	state DownloadState
-}
-
 // metadataMutex is used to limit a single go routine to write to the
 // metadata map when creating new map entries for all the different sub
 // reddits. These are later used for adding new entries for already
@@ -68,14 +63,44 @@ type Scraper struct {
 	// comes up again for any reason then we don't go and download this for the given sub.
 	// if it came up multiple times in multiple sub reddits, then it would be downloaded again.
 	uniqueImageIds map[string]map[string]bool
-	// The download image which will be designed to take in a pump of images, the listener
-	// will then fan out the images to many different go routines to downloadRedditMetadata all the images
-	// in need of downloading.
-	downloadImageChannel chan Image
-	// THe downloaded images once download will pump a message to this channel which will
-	// log back out to the user the information they are expecting to be notified that they
-	// have been downloaded.
-	downloadedMessagePumpChannel chan updateState
+	// imageResolvers are consulted for every parsed link so that hosts which hide more
+	// than one image behind a single post link (imgur albums/galleries today) can be
+	// expanded into the direct images they contain before downloading begins.
+	imageResolvers []ImageResolver
+	// metadataStore is the optional persistent dedup/history store. When nil, Mavic
+	// falls back to the in-memory uniqueImageIds map, meaning dedup only holds for
+	// the lifetime of the current run.
+	metadataStore *store.Store
+	// pipeline carries images from metadata fetching through to downloading, and
+	// status updates back out to whatever is driving the progress bar. It replaces
+	// the old single channel + semaphore pairing with two independently-tunable
+	// worker pools sitting on top of a pluggable message bus.
+	pipeline *pipeline.Pipeline
+	// oauthClient is the authenticated reddit client used when reddit API
+	// credentials are configured. When nil, gatherRedditFeed falls back to the
+	// anonymous .json endpoint, preserving the old casual-user behavior.
+	oauthClient *oauthClient
+}
+
+// WithStore opens (creating if needed) a sqlite-backed metadata store at path and
+// attaches it to the reddit reddit, so that dedup and download history survive
+// across runs. Without a store, Mavic falls back to its in-memory dedup map.
+func (s Scraper) WithStore(path string) Scraper {
+	metadataStore, err := store.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open metadata store at %q: %v\n", path, err)
+	}
+
+	s.metadataStore = metadataStore
+	return s
+}
+
+// WithBus swaps the pipeline's default in-process ChannelBus for a durable one
+// (e.g. pipeline.NewSQLBus), so that a message still in flight when Mavic
+// crashes or is restarted is retried instead of lost.
+func (s Scraper) WithBus(bus pipeline.Bus) Scraper {
+	s.pipeline.Bus = bus
+	return s
 }
 
 // Start is exposed and called into when a new Scraper is created, this is called
@@ -86,16 +111,30 @@ func (s Scraper) Start() {
 	// parsed.
 	progressBar = progressbar.NewOptions(1, progressbar.OptionSetRenderBlankState(true))
 
+	ctx := context.Background()
+	statusChannel, err := s.pipeline.Bus.Subscribe(ctx, pipeline.TopicDownloadStatus)
+
+	if err != nil {
+		log.Fatalf("Failed to subscribe to download status updates: %v\n", err)
+	}
+
 	go s.downloadRedditMetadata()
-	go s.downloadImages()
+	go s.downloadImages(ctx)
 
-	var downloaded, failed, skipped int
+	var downloaded, failed, skipped, processed int
+
+	for msg := range statusChannel {
+		var status statusMessage
+
+		if err := json.Unmarshal(msg.Payload, &status); err != nil {
+			fmt.Printf("Failed to decode download status update: %v\n", err)
+			continue
+		}
 
-	for msg := range s.downloadedMessagePumpChannel {
 		var downloadState string
 		var addingAmount = 1
 
-		switch msg.state {
+		switch status.State {
 		case DOWNLOADING:
 			downloadState = "Downloading"
 			addingAmount = 0
@@ -103,6 +142,7 @@ func (s Scraper) Start() {
 		case SUCCESS:
 			downloadState = "Downloaded"
 			downloaded += 1
+			addingAmount = 0
 			break
 		case SKIPPED:
 			downloadState = "Skipped"
@@ -112,14 +152,20 @@ func (s Scraper) Start() {
 			downloadState = "Failed Downloading"
 			failed += 1
 			break
+		case PROCESSED:
+			downloadState = "Processed"
+			processed += 1
+			break
 		}
 
-		progressBar.Describe(fmt.Sprintf("%s Image %s from r/%s...", downloadState, msg.image.imageId, msg.image.subreddit))
+		image := status.Image.toImage()
+		progressBar.Describe(fmt.Sprintf("%s Image %s from r/%s...", downloadState, image.imageId, image.subreddit))
 		_ = progressBar.Add(addingAmount)
+		_ = s.pipeline.Bus.Ack(msg)
 	}
 
-	progressBar.Describe(fmt.Sprintf("%v images processed. Downloaded %v, skipped %v and failed %v.",
-		progressBar.GetMax(), downloaded, skipped, failed))
+	progressBar.Describe(fmt.Sprintf("%v images processed. Downloaded %v, processed %v, skipped %v and failed %v.",
+		progressBar.GetMax(), downloaded, processed, skipped, failed))
 
 	_ = progressBar.Finish()
 }
@@ -135,9 +181,8 @@ func NewScraper(options Options) Scraper {
 			"controversial-hour": true, "controversial-week": true, "controversial-month": true,
 			"controversial-year": true, "controversial-all": true, "controversial": true,
 		},
-		uniqueImageIds:               map[string]map[string]bool{},
-		downloadImageChannel:         make(chan Image),
-		downloadedMessagePumpChannel: make(chan updateState),
+		uniqueImageIds: map[string]map[string]bool{},
+		imageResolvers: []ImageResolver{newImgurResolver()},
 	}
 
 	// we don't want to continue to process the data if the given page
@@ -147,8 +192,13 @@ func NewScraper(options Options) Scraper {
 		log.Fatalf("Invalid page type '%v' used, reference README for valid page types.\n", options.PageType)
 	}
 
-	if options.ImageLimit > 100 {
-		fmt.Println("Option 'limit' is currently enforced to 100 or les due ot a on going problem")
+	redditScraper.oauthClient, _ = newOAuthClient(options)
+
+	// the anonymous .json endpoint has no real paging support, so without reddit
+	// API credentials we keep enforcing the old 100-image cap. With credentials
+	// configured, gatherRedditFeed pages past it in chunks of 100 instead.
+	if redditScraper.oauthClient == nil && options.ImageLimit > 100 {
+		fmt.Println("Option 'limit' is currently enforced to 100 or less unless reddit API credentials are configured")
 		options.ImageLimit = 100
 	}
 
@@ -161,6 +211,8 @@ func NewScraper(options Options) Scraper {
 	}
 
 	redditScraper.scrapingOptions = options
+	redditScraper.pipeline = pipeline.NewPipeline(options.MaxConcurrentSubreddits, options.MaxConcurrentDownloads)
+
 	return redditScraper
 }
 
@@ -181,8 +233,14 @@ func (s Scraper) downloadMetadata(sub string, group *sync.WaitGroup) {
 
 	metadataMutex.Unlock()
 
-	listings, _ := s.gatherRedditFeed(sub)
+	listings, err := s.gatherRedditFeed(sub)
+	if err != nil {
+		fmt.Printf("Failed to gather r/%v's feed, skipping this tick: %v\n", sub, err)
+		return
+	}
+
 	links := parseLinksFromListings(listings)
+	images, failedResolutions := s.resolveImages(links)
 
 	dir := path.Join(s.scrapingOptions.OutputDirectory, sub)
 
@@ -196,67 +254,181 @@ func (s Scraper) downloadMetadata(sub string, group *sync.WaitGroup) {
 		_ = os.MkdirAll(dir, os.ModePerm)
 	}
 
-	// Update our progress bar to contain the newly updated max value.
-	// this max value will be a increase of the old value.
-	progressBar.ChangeMax(progressBar.GetMax() + len(links))
+	// Update our progress bar to contain the newly updated max value, including
+	// the resolutions that already failed so the bar doesn't overrun 100%.
+	// RunScheduled has no progress bar (it is only created by Start).
+	if progressBar != nil {
+		progressBar.ChangeMax(progressBar.GetMax() + len(images) + len(failedResolutions))
+	}
+
+	for _, image := range failedResolutions {
+		// reassign the sub reddit for the same reason as the loop below.
+		image.subreddit = sub
+
+		// transient, since the failure is most likely the resolver's own http
+		// request (a network blip, a host-side hiccup), not a permanent fact
+		// about the post itself.
+		s.publishStatus(image, FAILED)
+		s.persistState(image, store.StateFailedTransient, "", "", 0, "")
+	}
 
-	for _, image := range links {
+	for _, image := range images {
 
 		// reassign the sub reddit since it could be the front page and
 		// the front page folder is which we want the folder to enter into.
 		image.subreddit = sub
 
+		// if we have a persistent store, prefer it over the in-memory map since it
+		// also knows about images downloaded or permanently failed in past runs.
+		// Without one, fall back to uniqueImageIds, which only holds for the
+		// lifetime of the current run.
+		//
+		// guarded by metadataMutex since the scheduler can invoke downloadMetadata
+		// for the same sub from two overlapping cron ticks (a slow fetch outliving
+		// its own interval), which would otherwise race on this inner map.
+		if s.metadataStore != nil {
+			seen, err := s.metadataStore.Seen(sub, image.imageId)
+			if err != nil {
+				fmt.Printf("Failed to consult metadata store for %v: %v\n", image.imageId, err)
+			} else if seen {
+				continue
+			}
+		} else {
+			metadataMutex.Lock()
+			seen := s.uniqueImageIds[sub][image.imageId]
+			metadataMutex.Unlock()
+
+			if seen {
+				continue
+			}
+		}
+
+		metadataMutex.Lock()
 		s.uniqueImageIds[sub][image.imageId] = true
-		s.downloadImageChannel <- image
+		metadataMutex.Unlock()
+
+		s.publishImage(image)
+	}
+}
+
+// resolveImages expands every parsed image through the configured image resolvers,
+// allowing a single reddit post (e.g. an imgur album or gallery) to turn into many
+// direct, downloadable images. Images with no matching resolver are passed through
+// unchanged. Images whose matching resolver errors are returned separately in
+// failed, rather than silently dropped, so the caller can still surface a FAILED
+// status and persist them instead of the post just vanishing.
+func (s Scraper) resolveImages(images []Image) (resolved []Image, failed []Image) {
+	resolved = make([]Image, 0, len(images))
+
+	for _, image := range images {
+		var matched bool
+
+		for _, resolver := range s.imageResolvers {
+			if !resolver.Matches(image.link) {
+				continue
+			}
+
+			matched = true
+			urls, err := resolver.Resolve(image.link)
+
+			if err != nil {
+				fmt.Printf("Failed to resolve %v: %v\n", image.link, err)
+				failed = append(failed, image)
+				break
+			}
+
+			for _, url := range urls {
+				child := image
+				child.link = url
+				child.imageId = fmt.Sprintf("%s_%s", image.imageId, directImageHash(url))
+				resolved = append(resolved, child)
+			}
+
+			break
+		}
+
+		if !matched {
+			resolved = append(resolved, image)
+		}
 	}
+
+	return resolved, failed
 }
 
 // downloads all the metadata about all the different sub reddits which the user
 // as given to be downloaded. This is a requirement to gather the information that
-// will be used for the downloading process.
+// will be used for the downloading process. Bounded by MaxConcurrentSubreddits so
+// that a long list of subs doesn't hammer reddit all at once, independent of how
+// many image downloads are allowed to run at the same time.
 func (s Scraper) downloadRedditMetadata() {
 	var downloadWaitGroup sync.WaitGroup
 
+	ctx := context.Background()
+	sem := semaphore.NewWeighted(int64(s.pipeline.MaxConcurrentSubreddits))
+
 	for _, sub := range s.scrapingOptions.Subreddits {
 		downloadWaitGroup.Add(1)
-		go s.downloadMetadata(sub, &downloadWaitGroup)
+
+		if err := sem.Acquire(ctx, 1); err != nil {
+			fmt.Printf("Failed to acquire subreddit semaphore: %v\n", err)
+		}
+
+		go func(sub string) {
+			defer sem.Release(1)
+			s.downloadMetadata(sub, &downloadWaitGroup)
+		}(sub)
 	}
 
 	downloadWaitGroup.Wait()
-	close(s.downloadImageChannel)
+	_ = s.pipeline.Bus.CloseTopic(pipeline.TopicImageDownload)
 }
 
-// Iterates through the download image pump channel and constantly blocks
-// and takes the images pushed to it to be downloaded. calling into the
-// download image each time, until closed.
-func (s Scraper) downloadImages() {
+// Iterates through the pipeline's image.download topic and constantly blocks and
+// takes the images published to it to be downloaded. calling into the download
+// image each time, until the topic is closed or ctx is cancelled. Either way,
+// it waits for every download already in flight to finish before returning.
+func (s Scraper) downloadImages(ctx context.Context) {
 	var waitGroup sync.WaitGroup
 
-	ctx := context.Background()
-	sem := semaphore.NewWeighted(int64(s.scrapingOptions.MaxConcurrentDownloads))
+	sem := semaphore.NewWeighted(int64(s.pipeline.MaxConcurrentDownloads))
 
-	for img := range s.downloadImageChannel {
+	imageChannel, err := s.pipeline.Bus.Subscribe(ctx, pipeline.TopicImageDownload)
+	if err != nil {
+		log.Fatalf("Failed to subscribe to image downloads: %v\n", err)
+	}
+
+	for msg := range imageChannel {
 		waitGroup.Add(1)
 
 		if err := sem.Acquire(ctx, 1); err != nil {
 			fmt.Printf("Failed to acquire semaphore: %v\n", err)
 		}
 
-		go func(img Image) {
+		go func(msg pipeline.Message) {
+			defer waitGroup.Done()
+			defer sem.Release(1)
+
+			var imgMsg imageMessage
+			if err := json.Unmarshal(msg.Payload, &imgMsg); err != nil {
+				fmt.Printf("Failed to decode queued image: %v\n", err)
+				return
+			}
+
+			img := imgMsg.toImage()
 			s.downloadImage(path.Join(s.scrapingOptions.OutputDirectory, img.subreddit), img)
-			sem.Release(1)
-			waitGroup.Done()
-		}(img)
+			_ = s.pipeline.Bus.Ack(msg)
+		}(msg)
 	}
 
 	waitGroup.Wait()
-	close(s.downloadedMessagePumpChannel)
+	_ = s.pipeline.Bus.CloseTopic(pipeline.TopicDownloadStatus)
 }
 
 // downloadImage takes in the directory, image and sync group used to
 // download a given reddit image to a given directory.
 func (s Scraper) downloadImage(outDir string, img Image) {
-	s.downloadedMessagePumpChannel <- updateState{img, DOWNLOADING}
+	s.publishStatus(img, DOWNLOADING)
+	s.persistState(img, store.StatePending, "", "", 0, "")
 
 	// if we are just going into the root, remove everything after the last forward slash.
 	if s.scrapingOptions.RootFolderOnly {
@@ -280,7 +452,8 @@ func (s Scraper) downloadImage(outDir string, img Image) {
 	// posts.
 	imagePath := path.Join(outDir, imageId)
 	if _, fileErr := os.Stat(imagePath); !os.IsNotExist(fileErr) {
-		s.downloadedMessagePumpChannel <- updateState{img, SKIPPED}
+		s.publishStatus(img, SKIPPED)
+		s.persistState(img, store.StateSkipped, img.link, imagePath, 0, "")
 		return
 	}
 
@@ -288,9 +461,11 @@ func (s Scraper) downloadImage(outDir string, img Image) {
 
 	// early return if the os failed to create any of the folders, since there is
 	// no reason to attempt to download the file if we don't have any where to
-	// write the file to after wards.
+	// write the file to after wards. Transient, since a later run may find the
+	// path writable again.
 	if createErr != nil {
-		s.downloadedMessagePumpChannel <- updateState{img, FAILED}
+		s.publishStatus(img, FAILED)
+		s.persistState(img, store.StateFailedTransient, img.link, imagePath, 0, "")
 		return
 	}
 
@@ -298,39 +473,134 @@ func (s Scraper) downloadImage(outDir string, img Image) {
 	resp, httpErr := http.Get(img.link)
 
 	// early return if we failed to download the given file due to a
-	// unexpected http error.
+	// unexpected http error. Transient, since this is most likely a network
+	// blip rather than anything permanent about the post itself.
 	if httpErr != nil {
-		s.downloadedMessagePumpChannel <- updateState{img, FAILED}
+		s.publishStatus(img, FAILED)
+		s.persistState(img, store.StateFailedTransient, img.link, imagePath, 0, "")
 		return
 	}
 
 	defer Close(resp.Body)
-	_, ioErr := io.Copy(out, resp.Body)
 
+	hasher := sha256.New()
+	byteSize, ioErr := io.Copy(io.MultiWriter(out, hasher), resp.Body)
+
+	// also transient, for the same reason as the http.Get failure above.
 	if ioErr != nil {
-		s.downloadedMessagePumpChannel <- updateState{img, FAILED}
+		s.publishStatus(img, FAILED)
+		s.persistState(img, store.StateFailedTransient, img.link, imagePath, 0, "")
+		return
+	}
+
+	sha256Hash := hex.EncodeToString(hasher.Sum(nil))
+	s.publishStatus(img, SUCCESS)
+	s.persistState(img, store.StateSuccess, img.link, imagePath, byteSize, sha256Hash)
+
+	// downscale oversized images, reject undersized ones and generate a
+	// companion thumbnail. exempt for videos, which are still counted as processed.
+	if procErr := s.processImage(imagePath, img); procErr != nil {
+		fmt.Printf("Failed to process %v: %v\n", img.imageId, procErr)
+		s.publishStatus(img, FAILED)
+
+		// a below-minimum-resolution rejection is permanent, about the image
+		// itself; anything else (a corrupt partial write, a disk hiccup) is
+		// worth retrying on a later run.
+		failureState := store.StateFailedTransient
+		if errors.Is(procErr, ErrBelowMinResolution) {
+			failureState = store.StateFailed
+		}
+
+		s.persistState(img, failureState, img.link, imagePath, byteSize, sha256Hash)
+		return
+	}
+
+	// processImage may have rewritten imagePath in place (downscaling), so the
+	// size/hash captured from the original download no longer describe what's
+	// actually on disk. Recompute them rather than persisting stale values.
+	if processedSize, processedHash, hashErr := hashFile(imagePath); hashErr == nil {
+		byteSize, sha256Hash = processedSize, processedHash
+	} else {
+		fmt.Printf("Failed to refresh metadata for %v: %v\n", img.imageId, hashErr)
+	}
+
+	s.publishStatus(img, PROCESSED)
+	s.persistState(img, store.StateSuccess, img.link, imagePath, byteSize, sha256Hash)
+}
+
+// hashFile recomputes a file's size and sha256 from whatever is currently on
+// disk, used to refresh the metadata store after processImage has
+// potentially rewritten the file in place.
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer Close(file)
+
+	hasher := sha256.New()
+	size, err := io.Copy(hasher, file)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// persistState records a post/image's current download state into the metadata
+// store, when one is configured. It is a no-op otherwise, keeping the in-memory
+// map as the only source of truth for a run with no store attached.
+func (s Scraper) persistState(img Image, state store.State, downloadURL, localPath string, byteSize int64, sha256Hash string) {
+	if s.metadataStore == nil {
 		return
 	}
 
-	s.downloadedMessagePumpChannel <- updateState{img, SUCCESS}
+	record := store.Record{
+		Subreddit:   img.subreddit,
+		PostID:      img.id,
+		ImageID:     img.imageId,
+		Author:      img.author.name,
+		Permalink:   img.postLink,
+		SourceURL:   img.source,
+		DownloadURL: downloadURL,
+		LocalPath:   localPath,
+		ByteSize:    byteSize,
+		SHA256:      sha256Hash,
+		State:       state,
+	}
+
+	if err := s.metadataStore.Upsert(record); err != nil {
+		fmt.Printf("Failed to persist metadata for %v: %v\n", img.imageId, err)
+	}
 }
 
 // Downloads and parses the reddit json feed based on the sub reddit. Ensuring that
 // the sub reddit is not empty and ensuring that we send a valid user-agent to ensure
-// that reddit does not rate limit us
+// that reddit does not rate limit us. When reddit API credentials are configured,
+// this pages transparently through oauth.reddit.com instead of the anonymous,
+// 100-result-capped .json endpoint.
 func (s Scraper) gatherRedditFeed(sub string) (Listings, error) {
 	if strings.TrimSpace(sub) == "" {
 		return Listings{}, errors.New("sub reddit is required for downloading")
 	}
 
+	if s.oauthClient != nil {
+		return s.gatherRedditFeedAuthenticated(sub)
+	}
+
 	client := &http.Client{}
 	req, _ := http.NewRequest("GET", s.determineRedditUrl(sub), nil)
 	req.Header.Set("user-agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64)")
 
 	resp, err := client.Do(req)
 
+	// a transient network blip here used to take the whole process down via
+	// log.Panic. RunScheduled is meant to run unattended for days at a time,
+	// so a single failed tick must not be fatal — return the error and let
+	// the caller log and skip this tick instead.
 	if err != nil {
-		log.Panic(err)
+		return Listings{}, err
 	}
 
 	defer Close(resp.Body)
@@ -339,6 +609,41 @@ func (s Scraper) gatherRedditFeed(sub string) (Listings, error) {
 	return UnmarshalListing(body)
 }
 
+// gatherRedditFeedAuthenticated pages through sub via the authenticated
+// oauth.reddit.com endpoint, following reddit's "after" cursor in 100-result
+// chunks (reddit's own per-request cap) until ImageLimit worth of listings
+// have been gathered, merging every page's children together.
+func (s Scraper) gatherRedditFeedAuthenticated(sub string) (Listings, error) {
+	merged := Listings{Data: &ListingData{}}
+	after := ""
+
+	for len(merged.Data.Children) < s.scrapingOptions.ImageLimit {
+		body, err := s.oauthClient.get(s.determineRedditOAuthPath(sub, after))
+		if err != nil {
+			return merged, err
+		}
+
+		page, err := UnmarshalListing(body)
+		if err != nil {
+			return merged, err
+		}
+
+		if page.Data == nil || len(page.Data.Children) == 0 {
+			break
+		}
+
+		merged.Data.Children = append(merged.Data.Children, page.Data.Children...)
+
+		if page.Data.After == nil || strings.TrimSpace(*page.Data.After) == "" {
+			break
+		}
+
+		after = *page.Data.After
+	}
+
+	return merged, nil
+}
+
 // parseLinksFromListings parses all the links and core information out from
 // the listings into a more usable formatted listings to allow for a simpler
 // image downloading downloadRedditMetadata.
@@ -357,9 +662,10 @@ func parseLinksFromListings(listings Listings) []Image {
 
 			splitLink := strings.Split(*value.Data.URL, "/")
 
-			// ensure that we have not got a gallery or something, making sure that
-			// what we are downloading is a direct image and nothing else.
-			if strings.Contains(splitLink[len(splitLink)-1], ".") {
+			// a direct image has a file extension on its last path segment. anything
+			// without one is either junk or a host-specific album/gallery/single-page
+			// link that one of our image resolvers knows how to expand later on.
+			if strings.Contains(splitLink[len(splitLink)-1], ".") || isResolvableImgurLink(*value.Data.URL) {
 				filteredList = append(filteredList, value)
 			}
 		}
@@ -412,6 +718,26 @@ func (s Scraper) determineRedditUrl(sub string) string {
 	return url
 }
 
+// determineRedditOAuthPath builds the oauth.reddit.com request path for a
+// single page (100 results, reddit's own per-request cap) of a subreddit's
+// feed, continuing on from the given after cursor.
+func (s Scraper) determineRedditOAuthPath(sub, after string) string {
+	pageType := s.scrapingOptions.PageType
+	additional := ""
+
+	if strings.Contains(pageType, "-") {
+		pageSplit := strings.Split(pageType, "-")
+		additional = fmt.Sprintf("&t=%v", pageSplit[1])
+		pageType = pageSplit[0]
+	}
+
+	if sub == "frontpage" {
+		return fmt.Sprintf("/%v?limit=100&after=%v%v", pageType, after, additional)
+	}
+
+	return fmt.Sprintf("/r/%v/%v?limit=100&after=%v%v", sub, pageType, after, additional)
+}
+
 // Close is designed to handle a defer closed on a closer. Correctly and
 // fatally exiting if a error occurs on the close.
 func Close(c io.Closer) {
@@ -0,0 +1,139 @@
+// Package store persists everything Mavic has seen across runs so that a
+// restart can skip posts it has already resolved, and so there is a durable
+// record of what was downloaded, when, and from where.
+package store
+
+import (
+	"database/sql"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// State is the recorded outcome of a post/image's download. It mirrors
+// reddit.DownloadState but is kept independent so this package has no
+// dependency on the reddit package, which is the one depending on it.
+type State string
+
+const (
+	StatePending State = "PENDING"
+	StateSuccess State = "SUCCESS"
+	StateSkipped State = "SKIPPED"
+	// StateFailed marks a post/image as permanently rejected (e.g. it falls
+	// below the configured minimum resolution): retrying it on a later run
+	// would just reject it again, so Seen treats it as terminal.
+	StateFailed State = "FAILED"
+	// StateFailedTransient marks a download that failed for a reason that
+	// might not recur (a network blip, a momentarily-busy os.Create). Unlike
+	// StateFailed, Seen does not treat this as terminal, so a later run will
+	// retry it.
+	StateFailedTransient State = "FAILED_TRANSIENT"
+)
+
+// Record is a single tracked post/image, from first being seen through to
+// however its download was finally resolved.
+type Record struct {
+	Subreddit   string
+	PostID      string
+	ImageID     string
+	Author      string
+	Permalink   string
+	SourceURL   string
+	DownloadURL string
+	LocalPath   string
+	ByteSize    int64
+	SHA256      string
+	State       State
+}
+
+// Store wraps a sqlite database recording every post/image Mavic has come
+// across, used both for resumable cross-run dedup and as a foundation for
+// later query/report commands.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if needed) the sqlite database at path and ensures
+// the schema is up to date.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// migrate creates the records table on first use. It is safe to call on
+// every open since it is entirely additive.
+func (s *Store) migrate() error {
+	_, err := s.db.Exec(`
+CREATE TABLE IF NOT EXISTS records (
+	subreddit     TEXT NOT NULL,
+	post_id       TEXT NOT NULL,
+	image_id      TEXT NOT NULL,
+	author        TEXT,
+	permalink     TEXT,
+	source_url    TEXT,
+	download_url  TEXT,
+	local_path    TEXT,
+	byte_size     INTEGER,
+	sha256        TEXT,
+	state         TEXT NOT NULL,
+	first_seen_at TIMESTAMP NOT NULL,
+	updated_at    TIMESTAMP NOT NULL,
+	PRIMARY KEY (subreddit, image_id)
+);
+`)
+
+	return err
+}
+
+// Seen reports whether the given image has already reached a terminal state
+// (downloaded, or permanently failed) for the given subreddit, meaning
+// downloadMetadata can skip enqueueing it again. A StateFailedTransient
+// record is deliberately not terminal, so a later run retries it.
+func (s *Store) Seen(subreddit, imageId string) (bool, error) {
+	row := s.db.QueryRow(`SELECT state FROM records WHERE subreddit = ? AND image_id = ?`, subreddit, imageId)
+
+	var state string
+	switch err := row.Scan(&state); {
+	case err == sql.ErrNoRows:
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	return State(state) == StateSuccess || State(state) == StateFailed, nil
+}
+
+// Upsert records the current state of a post/image, inserting a new row the
+// first time it is seen and updating it on every later state transition.
+func (s *Store) Upsert(r Record) error {
+	now := time.Now().UTC()
+
+	_, err := s.db.Exec(`
+INSERT INTO records (subreddit, post_id, image_id, author, permalink, source_url, download_url, local_path, byte_size, sha256, state, first_seen_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (subreddit, image_id) DO UPDATE SET
+	download_url = excluded.download_url,
+	local_path   = excluded.local_path,
+	byte_size    = excluded.byte_size,
+	sha256       = excluded.sha256,
+	state        = excluded.state,
+	updated_at   = excluded.updated_at
+`, r.Subreddit, r.PostID, r.ImageID, r.Author, r.Permalink, r.SourceURL, r.DownloadURL, r.LocalPath, r.ByteSize, r.SHA256, string(r.State), now, now)
+
+	return err
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,89 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "mavic.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+func TestSeenUnknownRecord(t *testing.T) {
+	s := openTestStore(t)
+
+	seen, err := s.Seen("wallpapers", "abc123")
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+
+	if seen {
+		t.Error("expected an image never recorded to not be seen")
+	}
+}
+
+func TestSeenTerminality(t *testing.T) {
+	tests := []struct {
+		state State
+		want  bool
+	}{
+		{StatePending, false},
+		{StateSkipped, false},
+		{StateFailedTransient, false},
+		{StateFailed, true},
+		{StateSuccess, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.state), func(t *testing.T) {
+			s := openTestStore(t)
+
+			record := Record{Subreddit: "wallpapers", PostID: "p1", ImageID: "abc123", State: tt.state}
+			if err := s.Upsert(record); err != nil {
+				t.Fatalf("Upsert() error = %v", err)
+			}
+
+			seen, err := s.Seen("wallpapers", "abc123")
+			if err != nil {
+				t.Fatalf("Seen() error = %v", err)
+			}
+
+			if seen != tt.want {
+				t.Errorf("Seen() with state %v = %v, want %v", tt.state, seen, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpsertUpdatesExistingRecord(t *testing.T) {
+	s := openTestStore(t)
+
+	record := Record{Subreddit: "wallpapers", PostID: "p1", ImageID: "abc123", State: StatePending, ByteSize: 10, SHA256: "old"}
+	if err := s.Upsert(record); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	record.State = StateSuccess
+	record.ByteSize = 2048
+	record.SHA256 = "new"
+	if err := s.Upsert(record); err != nil {
+		t.Fatalf("Upsert() error = %v", err)
+	}
+
+	seen, err := s.Seen("wallpapers", "abc123")
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+
+	if !seen {
+		t.Error("expected the record to be seen after it was updated to StateSuccess")
+	}
+}